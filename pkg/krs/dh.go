@@ -1,6 +1,10 @@
 package krs
 
 import (
+	"crypto/rand"
+	"fmt"
+	"unsafe"
+
 	"golang.org/x/sys/unix"
 )
 
@@ -27,3 +31,114 @@ func ComputeDH(private, prime, base int32) ([]byte, error) {
 	_, err = unix.KeyctlDHCompute(&params, buffer)
 	return buffer, err
 }
+
+// kdfParams mirrors the kernel's struct keyctl_kdf_params, passed as the 5th keyctl(2) argument when
+// KEYCTL_DH_COMPUTE is asked to apply a KDF to the raw shared secret.
+type kdfParams struct {
+	hashName     *byte
+	otherInfo    *byte
+	otherInfoLen uint32
+	spare        [8]uint32
+}
+
+// ComputeDHKDF computes a Diffie-Hellman shared secret from private, prime and base, same as ComputeDH,
+// but additionally runs it through the kernel's SP800-56A-style KDF (named by hashName, e.g. "sha256",
+// with optional otherInfo context octets) before returning it, producing outLen bytes of derived key
+// material instead of the raw shared secret.
+func ComputeDHKDF(private, prime, base int32, hashName string, otherInfo []byte, outLen int) ([]byte, error) {
+	params := dhParam{
+		priv:  private,
+		prime: prime,
+		base:  base,
+	}
+
+	hashPtr, err := unix.BytePtrFromString(hashName)
+	if err != nil {
+		return nil, fmt.Errorf("BytePtrFromString failed: %v", err)
+	}
+
+	var otherPtr *byte
+	if len(otherInfo) > 0 {
+		otherPtr = &otherInfo[0]
+	}
+
+	kdf := kdfParams{
+		hashName:     hashPtr,
+		otherInfo:    otherPtr,
+		otherInfoLen: uint32(len(otherInfo)),
+	}
+
+	buffer := make([]byte, outLen)
+	_, _, errno := unix.Syscall6(
+		unix.SYS_KEYCTL,
+		unix.KEYCTL_DH_COMPUTE,
+		uintptr(unsafe.Pointer(&params)),
+		uintptr(unsafe.Pointer(&buffer[0])),
+		uintptr(len(buffer)),
+		uintptr(unsafe.Pointer(&kdf)),
+		0)
+	if errno != 0 {
+		var err error = errno
+		return nil, err
+	}
+	return buffer, nil
+}
+
+// DHKeys is a handle for an in-progress Diffie-Hellman key exchange: the ids of the prime, generator
+// and local private key backing it, so that a peer's public key can later be turned into a derived
+// session key.
+type DHKeys struct {
+	KeyringId int
+	PrimeId   int
+	BaseId    int
+	PrivateId int
+	PublicKey []byte
+}
+
+// DHKeyExchange generates a random private key for the given RFC 3526 group, stores it and the
+// group's prime and generator in a fresh keyring, and computes the corresponding local public key. The
+// returned handle's PublicKey is what the caller sends to its peer; DeriveKey then consumes the peer's
+// reply.
+func DHKeyExchange(size PrimeSize) (*DHKeys, error) {
+	params, err := DHAddPrimeKeys(size)
+	if err != nil {
+		return nil, fmt.Errorf("DHAddPrimeKeys failed: %v", err)
+	}
+
+	priv := make([]byte, uint16(size)/8)
+	if _, err := rand.Read(priv); err != nil {
+		return nil, fmt.Errorf("rand.Read failed: %v", err)
+	}
+	kd := NewKeyData(&priv)
+	privId, err := Add(params.KeyringId, TypeUser, "dh-private", kd)
+	if err != nil {
+		return nil, fmt.Errorf("Add failed: %v", err)
+	}
+
+	public, err := ComputeDH(int32(privId), int32(params.PrimeId), int32(params.BaseId))
+	if err != nil {
+		return nil, fmt.Errorf("ComputeDH failed: %v", err)
+	}
+
+	return &DHKeys{
+		KeyringId: params.KeyringId,
+		PrimeId:   params.PrimeId,
+		BaseId:    params.BaseId,
+		PrivateId: privId,
+		PublicKey: public,
+	}, nil
+}
+
+// DeriveKey consumes a peer's public key and returns a KDF-derived session key of outLen bytes, using
+// hashName (e.g. "sha256") and the optional otherInfo context octets, per SP800-56A.
+func (d *DHKeys) DeriveKey(peerPublic []byte, hashName string, otherInfo []byte, outLen int) ([]byte, error) {
+	data := append([]byte(nil), peerPublic...)
+	kd := NewKeyData(&data)
+	peerId, err := Add(d.KeyringId, TypeUser, "dh-peer-public", kd)
+	if err != nil {
+		return nil, fmt.Errorf("Add failed: %v", err)
+	}
+	defer Unlink(peerId, d.KeyringId)
+
+	return ComputeDHKDF(int32(d.PrivateId), int32(d.PrimeId), int32(peerId), hashName, otherInfo, outLen)
+}
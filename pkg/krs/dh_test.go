@@ -0,0 +1,36 @@
+package krs
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestDHKeyExchange01 exercises a full two-party exchange: each side generates its own private key
+// against the same RFC 3526 group, they swap public keys, and both derive the same session key.
+func TestDHKeyExchange01(t *testing.T) {
+	alice, err := DHKeyExchange(PrimeSize1536)
+	if err != nil {
+		t.Fatalf("TestDHKeyExchange01, alice DHKeyExchange failed: %v", err)
+	}
+	defer deleteKey(t, alice.KeyringId)
+
+	bob, err := DHKeyExchange(PrimeSize1536)
+	if err != nil {
+		t.Fatalf("TestDHKeyExchange01, bob DHKeyExchange failed: %v", err)
+	}
+	defer deleteKey(t, bob.KeyringId)
+
+	aliceKey, err := alice.DeriveKey(bob.PublicKey, "sha256", nil, 32)
+	if err != nil {
+		t.Fatalf("TestDHKeyExchange01, alice DeriveKey failed: %v", err)
+	}
+
+	bobKey, err := bob.DeriveKey(alice.PublicKey, "sha256", nil, 32)
+	if err != nil {
+		t.Fatalf("TestDHKeyExchange01, bob DeriveKey failed: %v", err)
+	}
+
+	if !bytes.Equal(aliceKey, bobKey) {
+		t.Fatalf("TestDHKeyExchange01, derived keys don't match: %v != %v", aliceKey, bobKey)
+	}
+}
@@ -181,11 +181,11 @@ func Clear(ringId int) error {
 
 // A Description of a key or keyring, as returned by the Describe method.
 type Description struct {
-	Type KeyType // TypeKey or TypeKeyring
-	UID  int     // User id
-	GID  int     // Group id
-	Perm uint32  // Access permissions
-	Desc string  // The description of the key or keyring, as set bey Add.
+	Type KeyType    // TypeUser or TypeKeyring
+	UID  int        // User id
+	GID  int        // Group id
+	Perm Permission // Access permissions
+	Desc string     // The description of the key or keyring, as set bey Add.
 }
 
 // Get a summary of key attributes.
@@ -206,42 +206,20 @@ func Describe(id int) (*Description, error) {
 		Type: tp,
 		UID:  uid,
 		GID:  gid,
-		Perm: uint32(perm),
+		Perm: Permission(perm),
 		Desc: parts[4],
 	}, nil
 }
 
+// dhParam mirrors the kernel's struct keyctl_dh_params, passed as the 2nd keyctl(2) argument for
+// KEYCTL_DH_COMPUTE. It is duplicated here instead of reusing unix.KeyctlDHParams so that
+// ComputeDHKDF can lay out its own raw Syscall6 call alongside the kdfParams it also needs to pass.
 type dhParam struct {
 	priv  int32
 	prime int32
 	base  int32
 }
 
-func DHCompute(params DHParameters) ([]byte, error) {
-	buffer := make([]byte, uint16(params.Size))
-
-	p := dhParam{
-		priv:  int32(params.PrivateKeyId),
-		prime: int32(params.PrimeId),
-		base:  int32(params.BaseId),
-	}
-
-	_, _, errno := unix.Syscall6(
-		unix.SYS_KEYCTL,
-		unix.KEYCTL_DH_COMPUTE,
-		uintptr(unsafe.Pointer(&p)),
-		uintptr(unsafe.Pointer(&buffer[0])),
-		uintptr(len(buffer)),
-		0, 0)
-
-	if errno != 0 {
-		var err error = errno
-		return nil, err
-	}
-
-	return buffer, nil
-}
-
 // Invalidate a key.
 // Marks a key as invalidated, and wakes up the garbage collector. The garbage collector immediately removes the key for
 // keyrings and deletes it, when it's reference count reaches zero.
@@ -270,13 +248,14 @@ func Read(id int) ([]int, error) {
 
 	if errno != 0 {
 		if int(r0) > 64 { // buffer to small
-			slices.Grow(buffer, (int(r0)-64)/4)
+			buffer = slices.Grow(buffer, (int(r0)-64)/4)
+			buffer = buffer[:int(r0)/4]
 			_, _, errno := unix.Syscall6(
 				unix.SYS_KEYCTL,
 				unix.KEYCTL_READ,
 				uintptr(id),
 				uintptr(unsafe.Pointer(&buffer[0])),
-				uintptr(r0),
+				uintptr(len(buffer)*4),
 				0,
 				0)
 			if errno != 0 {
@@ -291,6 +270,48 @@ func Read(id int) ([]int, error) {
 	return buffer[:(int(r0) / 4)], nil
 }
 
+// ReadPayload returns the raw payload of id. For a TypeUser key this is the secret bytes as they were
+// passed to Add or Update. For a TypeKeyring it falls back to Read's child-id decoding, re-encoded as
+// bytes.
+func ReadPayload(id int) ([]byte, error) {
+	desc, err := Describe(id)
+	if err != nil {
+		return nil, fmt.Errorf("Describe failed: %v", err)
+	}
+	if desc.Type != TypeUser {
+		words, err := Read(id)
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, 0, len(words)*4)
+		for _, w := range words {
+			buf = append(buf, byte(w), byte(w>>8), byte(w>>16), byte(w>>24))
+		}
+		return buf, nil
+	}
+
+	buffer := make([]byte, 128)
+	for {
+		r0, _, errno := unix.Syscall6(
+			unix.SYS_KEYCTL,
+			unix.KEYCTL_READ,
+			uintptr(id),                         // Key / keyring id.
+			uintptr(unsafe.Pointer(&buffer[0])), // Address of the buffer.
+			uintptr(len(buffer)),                // Size of the buffer in bytes.
+			0,
+			0,
+		)
+		if errno != 0 {
+			var err error = errno
+			return nil, err
+		}
+		if int(r0) <= len(buffer) {
+			return buffer[:r0], nil
+		}
+		buffer = make([]byte, int(r0))
+	}
+}
+
 func Revoke(id int) error {
 	return callWithInts(unix.KEYCTL_REVOKE, id, 0, 0, 0, 0)
 }
@@ -309,9 +330,8 @@ func Search(ringId int, keyType KeyType, desc string, destRingId int) (int, erro
 }
 
 // Set access permissions to a key or keyring.
-// The permission is a bit field, build up from the KEY_PERM_* constants. Setting a bit, wich is not covered by these
-// constants, is an error. Also the caller has to have the permissions to change the permission settings.
-func SetPermission(id int, perm uint32) error {
+// Also the caller has to have the permissions to change the permission settings.
+func SetPermission(id int, perm Permission) error {
 	_, _, errno := unix.Syscall6(
 		unix.SYS_KEYCTL,
 		unix.KEYCTL_SETPERM,
@@ -350,15 +370,19 @@ func SetTimeout(id int, timeout uint) error {
 func Update(id int, data *KeyData) error {
 	d, err := data.getData()
 	if err != nil {
-		fmt.Println("getData failed")
-		return err
+		return fmt.Errorf("getData failed: %v", err)
+	}
+
+	var ptr unsafe.Pointer
+	if len(*d) > 0 {
+		ptr = unsafe.Pointer(&(*d)[0])
 	}
 
 	_, _, errno := unix.Syscall6(
 		unix.SYS_KEYCTL,
 		unix.KEYCTL_UPDATE,
 		uintptr(id),
-		uintptr(unsafe.Pointer(&d)),
+		uintptr(ptr),
 		uintptr(len(*d)),
 		0, 0,
 	)
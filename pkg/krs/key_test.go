@@ -1,7 +1,7 @@
 package krs
 
 import (
-	"errors"
+	"bytes"
 	"fmt"
 	"os/exec"
 	"strconv"
@@ -69,7 +69,7 @@ func getDescription(t *testing.T, id int) (string, error) {
 }
 
 // Helper to get key permissions
-func getPermissions(t *testing.T, id int) (uint32, error) {
+func getPermissions(t *testing.T, id int) (Permission, error) {
 	keyName := fmt.Sprintf("%d", id)
 	out, err := exec.Command("keyctl", "describe", keyName).Output()
 	if err != nil {
@@ -77,31 +77,18 @@ func getPermissions(t *testing.T, id int) (uint32, error) {
 		return 0, err
 	}
 
-	perm := strings.Fields(string(out))[1]
-	if len(perm) != 24 {
-		t.Logf("getPermissions, invalid permissions length %d, expect 24", len(perm))
-		return 0, errors.New("Invalid permissions length")
-	}
-
-	var ret uint32 = 0
-	var n uint32 = 1
-	for i := 23; i >= 0; i-- {
-		if perm[i] != '-' {
-			ret |= n
-		}
-		n <<= 1
-		if i > 0 && i%6 == 0 {
-			n <<= 2
-		}
+	perm, err := ParsePermission(strings.Fields(string(out))[1])
+	if err != nil {
+		t.Logf("getPermissions, ParsePermission failed: %v", err)
+		return 0, err
 	}
-
-	return ret, nil
+	return perm, nil
 }
 
 // Helper to set key permissions
-func setPermissions(t *testing.T, id int, perm uint32) error {
+func setPermissions(t *testing.T, id int, perm Permission) error {
 	keyStr := fmt.Sprintf("%d", id)
-	permStr := fmt.Sprintf("%d", perm)
+	permStr := fmt.Sprintf("%d", uint32(perm))
 	cmd := exec.Command("keyctl", "setperm", keyStr, permStr)
 	err := cmd.Run()
 	if err != nil {
@@ -118,7 +105,7 @@ func TestGetPermissions(t *testing.T) {
 		t.Fatalf("TestgetPermissions01, start up returned error: %v", err)
 	}
 
-	var perm uint32 = 0x3F211705
+	perm := Permission(0x3F211705)
 	err = setPermissions(t, id, perm)
 	newPerm, err := getPermissions(t, id)
 	deleteKey(t, id)
@@ -155,7 +142,7 @@ func TestSetPermission01(t *testing.T) {
 	}
 
 	// Test case
-	var perm uint32 = 0x3F211705
+	perm := Permission(0x3F211705)
 	err = SetPermission(id, perm)
 	if err != nil {
 		deleteKey(t, id)
@@ -256,6 +243,29 @@ func TestSetTimeout01(t *testing.T) {
 	deleteKey(t, id)
 }
 
+func TestReadPayload01(t *testing.T) {
+	// Start up, create the key
+	data := []byte{1, 2, 3, 4, 5, 6, 7}
+	kd := NewKeyData(&data)
+	keyName := fmt.Sprintf("KRS-Test-%d", time.Now().Nanosecond())
+	id, err := Add(unix.KEY_SPEC_SESSION_KEYRING, TypeUser, keyName, kd)
+	if err != nil {
+		t.Fatalf("TestReadPayload01, Add returned error: %v", err)
+	}
+
+	// Test case
+	payload, err := ReadPayload(id)
+	deleteKey(t, id)
+	if err != nil {
+		t.Fatalf("TestReadPayload01, ReadPayload returned error: %v", err)
+	}
+
+	expected := []byte{1, 2, 3, 4, 5, 6, 7}
+	if !bytes.Equal(payload, expected) {
+		t.Fatalf("TestReadPayload01, got %v, expected %v", payload, expected)
+	}
+}
+
 func TestUpdate01(t *testing.T) {
 	// Start up, create the key
 	id, err := newKey(t, TypeUser, unix.KEY_SPEC_SESSION_KEYRING)
@@ -272,6 +282,14 @@ func TestUpdate01(t *testing.T) {
 		t.Fatalf("TestUpdate01, error: %v", err)
 	}
 
-	// Clean up
+	got, err := ReadPayload(id)
 	deleteKey(t, id)
+	if err != nil {
+		t.Fatalf("TestUpdate01, ReadPayload returned error: %v", err)
+	}
+
+	expected := []byte{1, 2, 3, 4, 5}
+	if !bytes.Equal(got, expected) {
+		t.Fatalf("TestUpdate01, got %v, expected %v", got, expected)
+	}
 }
@@ -0,0 +1,115 @@
+package krs
+
+import (
+	"fmt"
+)
+
+// Default permission mask applied to keys created through a Keyring: full possessor rights, plus
+// view/read/write/search for the owning user. Search must be granted or Set, Get and Remove — which all
+// locate the key via Search — won't be able to find it again once this mask is applied.
+var defaultKeyringPerm = Permission(0).
+	Set(ClassPossessor, RightView).
+	Set(ClassPossessor, RightRead).
+	Set(ClassPossessor, RightWrite).
+	Set(ClassPossessor, RightSearch).
+	Set(ClassPossessor, RightLink).
+	Set(ClassPossessor, RightSetAttr).
+	Set(ClassUser, RightView).
+	Set(ClassUser, RightRead).
+	Set(ClassUser, RightWrite).
+	Set(ClassUser, RightSearch)
+
+// Keyring is a credential-store style wrapper around a single keyring id, or one of the special
+// unix.KEY_SPEC_* scopes. It composes the lower level Search, Add, Update, Read and Unlink primitives
+// into an upsert-style Set/Get/Remove/Keys API, so callers don't have to juggle raw key ids themselves.
+type Keyring struct {
+	id   int
+	perm Permission
+}
+
+// OpenKeyring returns a Keyring bound to scope, which is one of "user", "session", "process", "thread",
+// or a decimal string naming an existing keyring id. Keys created through it get defaultKeyringPerm.
+func OpenKeyring(scope string) (*Keyring, error) {
+	return OpenKeyringWithPerm(scope, defaultKeyringPerm)
+}
+
+// OpenKeyringWithPerm is OpenKeyring, but lets the caller override the permission mask applied to keys
+// created through the returned Keyring instead of defaultKeyringPerm.
+func OpenKeyringWithPerm(scope string, perm Permission) (*Keyring, error) {
+	special, err := ScopeFromString(scope)
+	if err != nil {
+		return nil, err
+	}
+	id, err := GetKeyringID(special, true)
+	if err != nil {
+		return nil, fmt.Errorf("GetKeyringID failed: %v", err)
+	}
+	return &Keyring{id: id, perm: perm}, nil
+}
+
+// Set stores secret under name, creating the key if it doesn't already exist in the keyring, or
+// updating its payload in place otherwise. It returns the id of the (possibly newly created) key.
+func (k *Keyring) Set(name string, secret []byte) (int, error) {
+	data := append([]byte(nil), secret...)
+	kd := NewKeyData(&data)
+
+	if id, err := Search(k.id, TypeUser, name, 0); err == nil {
+		if err := Update(id, kd); err != nil {
+			return -1, fmt.Errorf("Update failed: %v", err)
+		}
+		return id, nil
+	}
+
+	id, err := Add(k.id, TypeUser, name, kd)
+	if err != nil {
+		return -1, fmt.Errorf("Add failed: %v", err)
+	}
+	if err := SetPermission(id, k.perm); err != nil {
+		return -1, fmt.Errorf("SetPermission failed: %v", err)
+	}
+	return id, nil
+}
+
+// Get retrieves the secret currently stored under name.
+func (k *Keyring) Get(name string) ([]byte, error) {
+	id, err := Search(k.id, TypeUser, name, 0)
+	if err != nil {
+		return nil, fmt.Errorf("Search failed: %v", err)
+	}
+
+	secret, err := ReadPayload(id)
+	if err != nil {
+		return nil, fmt.Errorf("ReadPayload failed: %v", err)
+	}
+	return secret, nil
+}
+
+// Remove deletes the key stored under name from the keyring.
+func (k *Keyring) Remove(name string) error {
+	id, err := Search(k.id, TypeUser, name, 0)
+	if err != nil {
+		return fmt.Errorf("Search failed: %v", err)
+	}
+	if err := Unlink(id, k.id); err != nil {
+		return fmt.Errorf("Unlink failed: %v", err)
+	}
+	return nil
+}
+
+// Keys returns the descriptions of all keys directly linked into the keyring.
+func (k *Keyring) Keys() ([]string, error) {
+	ids, err := Read(k.id)
+	if err != nil {
+		return nil, fmt.Errorf("Read failed: %v", err)
+	}
+
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		desc, err := Describe(id)
+		if err != nil {
+			return nil, fmt.Errorf("Describe failed: %v", err)
+		}
+		names = append(names, desc.Desc)
+	}
+	return names, nil
+}
@@ -0,0 +1,63 @@
+package krs
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestKeyringSetGetRemove01(t *testing.T) {
+	kr, err := OpenKeyring("session")
+	if err != nil {
+		t.Fatalf("TestKeyringSetGetRemove01, OpenKeyring failed: %v", err)
+	}
+
+	name := fmt.Sprintf("KRS-Test-%d", time.Now().Nanosecond())
+	secret := []byte{1, 2, 3, 4}
+
+	id, err := kr.Set(name, secret)
+	if err != nil {
+		t.Fatalf("TestKeyringSetGetRemove01, Set failed: %v", err)
+	}
+
+	got, err := kr.Get(name)
+	if err != nil {
+		deleteKey(t, id)
+		t.Fatalf("TestKeyringSetGetRemove01, Get failed: %v", err)
+	}
+	if !bytes.Equal(got, secret) {
+		deleteKey(t, id)
+		t.Fatalf("TestKeyringSetGetRemove01, got %v, expected %v", got, secret)
+	}
+
+	// Set again, this time taking the update path.
+	secret = []byte{5, 6, 7, 8}
+	if _, err := kr.Set(name, secret); err != nil {
+		deleteKey(t, id)
+		t.Fatalf("TestKeyringSetGetRemove01, update Set failed: %v", err)
+	}
+	got, err = kr.Get(name)
+	if err != nil {
+		deleteKey(t, id)
+		t.Fatalf("TestKeyringSetGetRemove01, Get after update failed: %v", err)
+	}
+	if !bytes.Equal(got, secret) {
+		deleteKey(t, id)
+		t.Fatalf("TestKeyringSetGetRemove01, got %v after update, expected %v", got, secret)
+	}
+
+	if err := kr.Remove(name); err != nil {
+		t.Fatalf("TestKeyringSetGetRemove01, Remove failed: %v", err)
+	}
+
+	if _, err := kr.Get(name); err == nil {
+		t.Fatalf("TestKeyringSetGetRemove01, Get succeeded after Remove")
+	}
+}
+
+func TestKeyringOpenInvalidScope(t *testing.T) {
+	if _, err := OpenKeyring("not-a-scope"); err == nil {
+		t.Fatalf("TestKeyringOpenInvalidScope, expected an error for an invalid scope")
+	}
+}
@@ -0,0 +1,100 @@
+package krs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PermClass identifies one of the four access classes a Permission's rights can be granted to.
+type PermClass uint8
+
+const (
+	ClassOther PermClass = iota
+	ClassGroup
+	ClassUser
+	ClassPossessor
+)
+
+// PermRight identifies one of the six rights that can be granted to a PermClass.
+type PermRight uint8
+
+const (
+	RightView PermRight = iota
+	RightRead
+	RightWrite
+	RightSearch
+	RightLink
+	RightSetAttr
+)
+
+// rightLetters maps a PermRight to the character keyctl uses for it in its symbolic permission string.
+var rightLetters = [...]byte{'v', 'r', 'w', 's', 'l', 'a'}
+
+// classOrder is the left-to-right order classes appear in in keyctl's symbolic permission string.
+var classOrder = [...]PermClass{ClassPossessor, ClassUser, ClassGroup, ClassOther}
+
+// Permission is the access mask of a key or keyring, as used by SetPermission and returned by Describe.
+// It is built up with Set, instead of the raw uint32 bit field keyctl itself expects.
+type Permission uint32
+
+func bitFor(class PermClass, right PermRight) Permission {
+	return 1 << (uint(class)*8 + uint(right))
+}
+
+// Set returns a copy of p with right granted to class.
+func (p Permission) Set(class PermClass, right PermRight) Permission {
+	return p | bitFor(class, right)
+}
+
+// Has reports whether right is granted to class in p.
+func (p Permission) Has(class PermClass, right PermRight) bool {
+	return p&bitFor(class, right) != 0
+}
+
+// String renders p as the 24 character symbolic string keyctl describe prints, e.g.
+// "alswrv--s---------------" for a key that is fully possessor-owned plus user-searchable.
+func (p Permission) String() string {
+	var b strings.Builder
+	for _, class := range classOrder {
+		for right := RightSetAttr; ; right-- {
+			if p.Has(class, right) {
+				b.WriteByte(rightLetters[right])
+			} else {
+				b.WriteByte('-')
+			}
+			if right == RightView {
+				break
+			}
+		}
+	}
+	return b.String()
+}
+
+// ParsePermission parses the 24 character symbolic permission string produced by String (and by keyctl
+// describe) back into a Permission.
+func ParsePermission(s string) (Permission, error) {
+	if len(s) != len(classOrder)*len(rightLetters) {
+		return 0, fmt.Errorf("invalid permission string length %d, expected %d", len(s), len(classOrder)*len(rightLetters))
+	}
+
+	var p Permission
+	i := 0
+	for _, class := range classOrder {
+		for right := RightSetAttr; ; right-- {
+			c := s[i]
+			i++
+			switch {
+			case c == '-':
+				// no right granted
+			case c == rightLetters[right]:
+				p = p.Set(class, right)
+			default:
+				return 0, fmt.Errorf("unexpected character %q at position %d", c, i-1)
+			}
+			if right == RightView {
+				break
+			}
+		}
+	}
+	return p, nil
+}
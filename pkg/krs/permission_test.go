@@ -0,0 +1,48 @@
+package krs
+
+import "testing"
+
+func TestPermissionSetHas01(t *testing.T) {
+	rights := []PermRight{RightView, RightRead, RightWrite, RightSearch, RightLink, RightSetAttr}
+
+	var p Permission
+	for _, right := range rights {
+		p = p.Set(ClassPossessor, right)
+	}
+
+	for _, right := range rights {
+		if !p.Has(ClassPossessor, right) {
+			t.Fatalf("TestPermissionSetHas01, expected ClassPossessor to have right %v", right)
+		}
+		if p.Has(ClassUser, right) {
+			t.Fatalf("TestPermissionSetHas01, did not expect ClassUser to have right %v", right)
+		}
+	}
+}
+
+func TestPermissionStringRoundTrip01(t *testing.T) {
+	p := Permission(0x3F211705)
+
+	s := p.String()
+	if len(s) != 24 {
+		t.Fatalf("TestPermissionStringRoundTrip01, String returned length %d, expected 24", len(s))
+	}
+
+	got, err := ParsePermission(s)
+	if err != nil {
+		t.Fatalf("TestPermissionStringRoundTrip01, ParsePermission returned error: %v", err)
+	}
+	if got != p {
+		t.Fatalf("TestPermissionStringRoundTrip01, got %#x, expected %#x", uint32(got), uint32(p))
+	}
+}
+
+func TestParsePermissionInvalid01(t *testing.T) {
+	if _, err := ParsePermission("tooshort"); err == nil {
+		t.Fatalf("TestParsePermissionInvalid01, expected an error for an invalid length")
+	}
+	bad := "-----------------------x" // last character must be 'v' or '-', not 'x'
+	if _, err := ParsePermission(bad); err == nil {
+		t.Fatalf("TestParsePermissionInvalid01, expected an error for an invalid character")
+	}
+}
@@ -0,0 +1,66 @@
+package krs
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// RequestKey searches for a key of type tp and description desc, same as Search, but if no such key is
+// found it invokes the /sbin/request-key upcall (with calloutInfo passed through to it) to have one
+// instantiated, per request_key(2). If destRingId is not zero, the resulting key is linked to it.
+func RequestKey(tp KeyType, desc, calloutInfo string, destRingId int) (int, error) {
+	t, err := KeyTypeToString(tp)
+	if err != nil {
+		return -1, fmt.Errorf("KeyTypeToString failed: %v", err)
+	}
+
+	typePtr, err := unix.BytePtrFromString(t)
+	if err != nil {
+		return -1, err
+	}
+	descPtr, err := unix.BytePtrFromString(desc)
+	if err != nil {
+		return -1, err
+	}
+
+	var calloutPtr *byte
+	if calloutInfo != "" {
+		calloutPtr, err = unix.BytePtrFromString(calloutInfo)
+		if err != nil {
+			return -1, err
+		}
+	}
+
+	r0, _, errno := unix.Syscall6(
+		unix.SYS_REQUEST_KEY,
+		uintptr(unsafe.Pointer(typePtr)),
+		uintptr(unsafe.Pointer(descPtr)),
+		uintptr(unsafe.Pointer(calloutPtr)),
+		uintptr(destRingId),
+		0, 0,
+	)
+	if errno != 0 {
+		var err error = errno
+		return -1, err
+	}
+	return int(r0), nil
+}
+
+// GetPersistent obtains the per-UID persistent keyring for uid, creating it if it doesn't yet exist, and
+// links it into the keyring destRingId. Passing -1 for uid targets the invoking process's own UID.
+func GetPersistent(uid, destRingId int) (int, error) {
+	r0, _, errno := unix.Syscall6(
+		unix.SYS_KEYCTL,
+		unix.KEYCTL_GET_PERSISTENT,
+		uintptr(uid),
+		uintptr(destRingId),
+		0, 0, 0,
+	)
+	if errno != 0 {
+		var err error = errno
+		return -1, err
+	}
+	return int(r0), nil
+}
@@ -0,0 +1,26 @@
+package krs
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestRequestKeyNoHandler01(t *testing.T) {
+	desc := fmt.Sprintf("KRS-Test-RequestKey-%d", time.Now().Nanosecond())
+	if _, err := RequestKey(TypeUser, desc, "", 0); err == nil {
+		t.Fatalf("TestRequestKeyNoHandler01, expected an error for a key with no request-key handler")
+	}
+}
+
+func TestGetPersistent01(t *testing.T) {
+	id, err := GetPersistent(-1, unix.KEY_SPEC_SESSION_KEYRING)
+	if err != nil {
+		t.Fatalf("TestGetPersistent01, GetPersistent returned error: %v", err)
+	}
+	if id <= 0 {
+		t.Fatalf("TestGetPersistent01, got invalid keyring id: %d", id)
+	}
+}
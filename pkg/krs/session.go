@@ -0,0 +1,96 @@
+package krs
+
+import (
+	"fmt"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+)
+
+// Named special keyring scopes, mirroring the full set of unix.KEY_SPEC_* ids.
+const (
+	ScopeThread      = unix.KEY_SPEC_THREAD_KEYRING
+	ScopeProcess     = unix.KEY_SPEC_PROCESS_KEYRING
+	ScopeSession     = unix.KEY_SPEC_SESSION_KEYRING
+	ScopeUser        = unix.KEY_SPEC_USER_KEYRING
+	ScopeUserSession = unix.KEY_SPEC_USER_SESSION_KEYRING
+	ScopeGroup       = unix.KEY_SPEC_GROUP_KEYRING
+)
+
+// ScopeFromString parses one of "thread", "process", "session", "user", "user-session" or "group" into
+// the matching unix.KEY_SPEC_* id. A decimal string is parsed as the id of an existing keyring.
+func ScopeFromString(s string) (int, error) {
+	switch s {
+	case "thread":
+		return ScopeThread, nil
+	case "process":
+		return ScopeProcess, nil
+	case "session":
+		return ScopeSession, nil
+	case "user":
+		return ScopeUser, nil
+	case "user-session":
+		return ScopeUserSession, nil
+	case "group":
+		return ScopeGroup, nil
+	default:
+		id, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, fmt.Errorf("unsupported keyring scope: %s", s)
+		}
+		return id, nil
+	}
+}
+
+// GetKeyringID resolves one of the special keyring ids to the real id of the keyring it currently
+// refers to. If create is true and the keyring doesn't exist yet, it is created first.
+func GetKeyringID(special int, create bool) (int, error) {
+	c := 0
+	if create {
+		c = 1
+	}
+	r0, _, errno := unix.Syscall6(
+		unix.SYS_KEYCTL,
+		unix.KEYCTL_GET_KEYRING_ID,
+		uintptr(special),
+		uintptr(c),
+		0, 0, 0,
+	)
+	if errno != 0 {
+		var err error = errno
+		return -1, err
+	}
+	return int(r0), nil
+}
+
+// JoinSessionKeyring makes the invoking thread join the session keyring named name, creating it if it
+// doesn't already exist. If name is empty, a new anonymous session keyring is created and joined. It
+// returns the id of the joined keyring.
+func JoinSessionKeyring(name string) (int, error) {
+	if name != "" {
+		return unix.KeyctlJoinSessionKeyring(name)
+	}
+	// The kernel only joins an anonymous session keyring for a NULL name; an empty string is rejected.
+	r0, _, errno := unix.Syscall6(
+		unix.SYS_KEYCTL,
+		unix.KEYCTL_JOIN_SESSION_KEYRING,
+		0,
+		0, 0, 0, 0,
+	)
+	if errno != 0 {
+		var err error = errno
+		return -1, err
+	}
+	return int(r0), nil
+}
+
+// LinkSessionToUser links the calling process's user keyring into its session keyring.
+func LinkSessionToUser() error {
+	return Link(unix.KEY_SPEC_USER_KEYRING, unix.KEY_SPEC_SESSION_KEYRING)
+}
+
+// Chown changes the user and group ownership of a key or keyring. Passing -1 for either uid or gid
+// leaves that value unchanged.
+func Chown(id, uid, gid int) error {
+	return callWithInts(unix.KEYCTL_CHOWN, id, uid, gid, 0, 0)
+}
@@ -0,0 +1,61 @@
+package krs
+
+import "testing"
+
+func TestScopeFromString01(t *testing.T) {
+	cases := map[string]int{
+		"thread":       ScopeThread,
+		"process":      ScopeProcess,
+		"session":      ScopeSession,
+		"user":         ScopeUser,
+		"user-session": ScopeUserSession,
+		"group":        ScopeGroup,
+		"123":          123,
+	}
+
+	for s, want := range cases {
+		got, err := ScopeFromString(s)
+		if err != nil {
+			t.Fatalf("ScopeFromString(%q) returned error: %v", s, err)
+		}
+		if got != want {
+			t.Fatalf("ScopeFromString(%q) = %d, expected %d", s, got, want)
+		}
+	}
+
+	if _, err := ScopeFromString("not-a-scope"); err == nil {
+		t.Fatalf("ScopeFromString, expected an error for an invalid scope")
+	}
+}
+
+func TestGetKeyringID01(t *testing.T) {
+	id, err := GetKeyringID(ScopeSession, true)
+	if err != nil {
+		t.Fatalf("TestGetKeyringID01, GetKeyringID returned error: %v", err)
+	}
+	if id <= 0 {
+		t.Fatalf("TestGetKeyringID01, got invalid keyring id: %d", id)
+	}
+}
+
+func TestJoinSessionKeyring01(t *testing.T) {
+	id, err := JoinSessionKeyring("")
+	if err != nil {
+		t.Fatalf("TestJoinSessionKeyring01, JoinSessionKeyring returned error: %v", err)
+	}
+	if id <= 0 {
+		t.Fatalf("TestJoinSessionKeyring01, got invalid keyring id: %d", id)
+	}
+}
+
+func TestLinkSessionToUser01(t *testing.T) {
+	if err := LinkSessionToUser(); err != nil {
+		t.Fatalf("TestLinkSessionToUser01, LinkSessionToUser returned error: %v", err)
+	}
+}
+
+func TestChownInvalidId01(t *testing.T) {
+	if err := Chown(-12345, -1, -1); err == nil {
+		t.Fatalf("TestChownInvalidId01, expected an error for an invalid key id")
+	}
+}